@@ -0,0 +1,41 @@
+package gcloudsql
+
+import "fmt"
+
+// APIError : Typed decoding of the error envelope the SQL Admin API returns
+// on non-200 responses (`{"error":{"code":..,"message":..,"errors":[...]}}`),
+// letting callers distinguish quota, auth, and validation failures by Reason
+// instead of matching on an opaque error string.
+type APIError struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Errors  []APIErrorDetail `json:"errors"`
+}
+
+// APIErrorDetail : A single entry in APIError.Errors
+type APIErrorDetail struct {
+	Domain  string `json:"domain"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// apiErrorEnvelope : Wrapper struct matching the top-level "error" key Google
+// APIs wrap error responses in
+type apiErrorEnvelope struct {
+	Error APIError `json:"error"`
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("gcloudsql: %s (code %d)", e.Message, e.Code)
+}
+
+// Reason : Returns the reason of the first detail entry, or "" if none was
+// present. Google populates this with machine-readable values such as
+// "quotaExceeded", "authError", or "invalid" that callers can switch on.
+func (e APIError) Reason() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+
+	return e.Errors[0].Reason
+}
@@ -0,0 +1,141 @@
+package gcloudsql
+
+import "context"
+
+// BackupRun : Struct for storing sql backup run data
+type BackupRun struct {
+	Kind            string `json:"kind"`
+	Status          string `json:"status"`
+	EnqueuedTime    string `json:"enqueuedTime"`
+	ID              int64  `json:"id,string"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	Type            string `json:"type"`
+	WindowStartTime string `json:"windowStartTime"`
+	Instance        string `json:"instance"`
+	SelfLink        string `json:"selfLink"`
+}
+
+// backupRunsListResponse : Envelope returned by the SQL Admin backupRuns.list endpoint
+type backupRunsListResponse struct {
+	Kind  string      `json:"kind"`
+	Items []BackupRun `json:"items"`
+}
+
+// ListBackupRuns : Lists the backup runs recorded for the instance
+func (c *Connection) ListBackupRuns(ctx context.Context) ([]BackupRun, error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return nil, err
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: backupRunsRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	httpRequest, err := NewHTTPRequest(ctx, "GET", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResponse backupRunsListResponse
+	if err := ParseHTTPRequest(c.httpClient, httpRequest, &listResponse); err != nil {
+		return nil, err
+	}
+
+	return listResponse.Items, nil
+}
+
+// TriggerBackup : Starts an on-demand backup run of the instance
+func (c *Connection) TriggerBackup(ctx context.Context) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: backupRunsRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+		bodyText: backupRunsInsertBodyTemplate,
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "POST", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
+
+// RestoreBackup : Restores the instance to the state captured by backupRunID
+func (c *Connection) RestoreBackup(ctx context.Context, backupRunID int64) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: restoreBackupURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+		bodyText: restoreBackupBodyTemplate,
+		bodyData: struct {
+			BackupRunID int64
+		}{
+			backupRunID,
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "POST", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
@@ -0,0 +1,145 @@
+package gcloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/eolso/gcloudsql/gcloudsqltest"
+)
+
+func TestListBackupRuns(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body: map[string]interface{}{
+				"kind": "sql#backupRunsList",
+				"items": []map[string]interface{}{
+					{"kind": "sql#backupRun", "status": "SUCCESSFUL", "id": "1234567890123", "instance": "inst"},
+				},
+			},
+		},
+		{
+			name:       "api error",
+			statusCode: http.StatusForbidden,
+			body:       apiErrorBody("quotaExceeded"),
+			wantErr:    "quotaExceeded",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodGet, "/sql/v1beta4/projects/proj/instances/inst/backupRuns", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			runs, err := c.ListBackupRuns(context.Background())
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(runs) != 1 || runs[0].ID != 1234567890123 {
+				t.Fatalf("unexpected backup runs: %+v", runs)
+			}
+		})
+	}
+}
+
+func TestTriggerBackup(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusForbidden, body: apiErrorBody("invalid"), wantErr: "invalid"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodPost, "/sql/v1beta4/projects/proj/instances/inst/backupRuns", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.TriggerBackup(context.Background())
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestRestoreBackup also guards against the restoreBackupBodyTemplate
+// regressing to rendering backupRunId as a bare JSON number: BackupRun.ID is
+// tagged json:"id,string", so an unquoted backupRunId here would fail to
+// unmarshal into the string field below.
+func TestRestoreBackup(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusForbidden, body: apiErrorBody("invalid"), wantErr: "invalid"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodPost, "/sql/v1beta4/projects/proj/instances/inst/restoreBackup", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.RestoreBackup(context.Background(), 1234567890123)
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			requests := server.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(requests))
+			}
+
+			var sent struct {
+				RestoreBackupContext struct {
+					Kind        string `json:"kind"`
+					BackupRunID string `json:"backupRunId"`
+				} `json:"restoreBackupContext"`
+			}
+			if err := json.Unmarshal(requests[0].Body, &sent); err != nil {
+				t.Fatalf("decoding request body (backupRunId must be a quoted string): %v", err)
+			}
+			if sent.RestoreBackupContext.BackupRunID != "1234567890123" {
+				t.Fatalf("unexpected backupRunId: %q", sent.RestoreBackupContext.BackupRunID)
+			}
+		})
+	}
+}
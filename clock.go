@@ -0,0 +1,36 @@
+package gcloudsql
+
+import (
+	"context"
+	"time"
+)
+
+// Clock : Abstracts time.Now so callers (tests, in particular) can control
+// elapsed time without actually waiting
+type Clock interface {
+	Now() time.Time
+}
+
+// Sleeper : Abstracts blocking until d has elapsed or ctx is cancelled, so
+// waitUntilDone's polling loop can be driven deterministically in tests
+// instead of sleeping in real time
+type Sleeper interface {
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// realClock : Default Clock backed by the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// realSleeper : Default Sleeper backed by time.After
+type realSleeper struct{}
+
+func (realSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -1,25 +1,53 @@
 package gcloudsql
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 
-	"github.com/briandowns/spinner"
+	"golang.org/x/oauth2"
 )
 
 var ErrNoPublicIP = errors.New("No public IP found")
 
+// defaultPollInterval : Starting interval between waitUntilDone polls
+const defaultPollInterval = 1 * time.Second
+
+// maxPollInterval : Ceiling applied to the exponential poll backoff
+const maxPollInterval = 30 * time.Second
+
 // Connection : Struct for storing relevant gcloud sql connection data
 type Connection struct {
-	Instance    SQLInstance
-	accessToken AccessToken
-	httpRequest *http.Request
-	response    Response
-	lock        *sync.Mutex
+	Instance     SQLInstance
+	tokenSource  oauth2.TokenSource
+	httpClient   *http.Client
+	pollInterval time.Duration
+	progress     ProgressReporter
+	clock        Clock
+	sleeper      Sleeper
+	httpRequest  *http.Request
+	response     Response
+	lock         *sync.Mutex
+}
+
+// ConnectionOptions : Optional dependencies for NewConnectionWithOptions.
+// Any zero-valued field falls back to its package default. Transport is
+// ignored when HTTPClient is set; it exists so tests can redirect requests
+// to a fake server (see the gcloudsqltest subpackage) without having to
+// build a whole *http.Client.
+type ConnectionOptions struct {
+	HTTPClient       *http.Client
+	Transport        http.RoundTripper
+	TokenSource      oauth2.TokenSource
+	PollInterval     time.Duration
+	ProgressReporter ProgressReporter
+	Clock            Clock
+	Sleeper          Sleeper
 }
 
 // SQLInstance : Struct for storing sql relevant sql instance data
@@ -71,8 +99,55 @@ type Response struct {
 }
 
 // NewConnection : Creates a new Connection from a specified projectID, instanceName
-func NewConnection(projectID string, instanceName string) (c Connection, err error) {
-	accessToken, err := GenerateAccessToken()
+func NewConnection(ctx context.Context, projectID string, instanceName string) (c Connection, err error) {
+	return NewConnectionWithOptions(ctx, projectID, instanceName, ConnectionOptions{})
+}
+
+// NewConnectionWithOptions : Creates a new Connection, letting callers
+// override the token source, transport/client, waitUntilDone poll interval,
+// progress reporting, and clock/sleeper used for polling
+func NewConnectionWithOptions(ctx context.Context, projectID string, instanceName string, opts ConnectionOptions) (c Connection, err error) {
+	tokenSource := opts.TokenSource
+	if tokenSource == nil {
+		tokenSource, err = NewTokenSource(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		transport := opts.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	progress := opts.ProgressReporter
+	if progress == nil {
+		progress = noopProgressReporter{}
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	sleeper := opts.Sleeper
+	if sleeper == nil {
+		sleeper = realSleeper{}
+	}
+
+	bearer, err := bearerToken(tokenSource)
+	if err != nil {
+		return
+	}
 
 	request := TemplatedHTTPRequest{
 		urlText: instanceRequestURLTemplate,
@@ -84,50 +159,66 @@ func NewConnection(projectID string, instanceName string) (c Connection, err err
 			instanceName,
 		},
 		headers: map[string]string{
-			"Authorization": "Bearer " + accessToken.token,
+			"Authorization": "Bearer " + bearer,
 			"Content-Type":  "application/json",
 		},
 	}
 
-	httpRequest, err := NewHTTPRequest("GET", request)
+	httpRequest, err := NewHTTPRequest(ctx, "GET", request)
 	if err != nil {
 		return
 	}
 
 	var sqlInstance SQLInstance
-	err = ParseHTTPRequest(httpRequest, &sqlInstance)
+	err = ParseHTTPRequest(httpClient, httpRequest, &sqlInstance)
 	if err != nil {
 		return
 	}
 
 	c.Instance = sqlInstance
-	c.accessToken = accessToken
+	c.tokenSource = tokenSource
+	c.httpClient = httpClient
+	c.pollInterval = pollInterval
+	c.progress = progress
+	c.clock = clock
+	c.sleeper = sleeper
 	c.lock = new(sync.Mutex)
 
 	return
 }
 
+// bearerToken : Retrieves the current access token from a TokenSource,
+// refreshing it if necessary
+func bearerToken(tokenSource oauth2.TokenSource) (string, error) {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
 // GetResponse : Returns the last response held by the connection
 func (c Connection) GetResponse() Response {
 	return c.response
 }
 
 // EnableSSL : enables the ssl required restriction on the instance
-func (c *Connection) EnableSSL() error {
+func (c *Connection) EnableSSL(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	return c.modifySSLPolicy(true)
+	return c.modifySSLPolicy(ctx, true)
 }
 
 // DisableSSL : Disables the ssl required restriction on the instance
-func (c *Connection) DisableSSL() error {
+func (c *Connection) DisableSSL(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	return c.modifySSLPolicy(false)
+	return c.modifySSLPolicy(ctx, false)
 }
 
 // WhitelistIP : Adds an entry to the instance authorized networks
-func (c *Connection) WhitelistIP(name string, value string) error {
+func (c *Connection) WhitelistIP(ctx context.Context, name string, value string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -140,11 +231,11 @@ func (c *Connection) WhitelistIP(name string, value string) error {
 	updatedNetworks := c.Instance.Settings.IPConfiguration.AuthorizedNetworks
 	updatedNetworks = append(updatedNetworks, newNetwork)
 
-	return c.updateAuthorizedNetworks(updatedNetworks)
+	return c.updateAuthorizedNetworks(ctx, updatedNetworks)
 }
 
 // BlacklistIP : Searches for specified value in whitelist and removes it
-func (c *Connection) BlacklistIP(value string) error {
+func (c *Connection) BlacklistIP(ctx context.Context, value string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -157,10 +248,15 @@ func (c *Connection) BlacklistIP(value string) error {
 		}
 	}
 
-	return c.updateAuthorizedNetworks(updatedNetworks)
+	return c.updateAuthorizedNetworks(ctx, updatedNetworks)
 }
 
-func (c *Connection) updateAuthorizedNetworks(networks []AuthorizedNetwork) (err error) {
+func (c *Connection) updateAuthorizedNetworks(ctx context.Context, networks []AuthorizedNetwork) (err error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
 	request := TemplatedHTTPRequest{
 		urlText: instanceRequestURLTemplate,
 		urlData: struct {
@@ -171,27 +267,38 @@ func (c *Connection) updateAuthorizedNetworks(networks []AuthorizedNetwork) (err
 			c.Instance.Name,
 		},
 		headers: map[string]string{
-			"Authorization": "Bearer " + c.accessToken.token,
+			"Authorization": "Bearer " + bearer,
 			"Content-Type":  "application/json",
 		},
 		bodyText: instanceRequestBodyTemplate,
 		bodyData: networks,
 	}
 
-	c.httpRequest, err = NewHTTPRequest("PATCH", request)
+	c.httpRequest, err = NewHTTPRequest(ctx, "PATCH", request)
 	if err != nil {
 		return
 	}
 
-	err = ParseHTTPRequest(c.httpRequest, &c.response)
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
 	if err != nil {
 		return
 	}
 
-	return c.waitUntilDone()
+	if err = c.waitUntilDone(ctx); err != nil {
+		return
+	}
+
+	c.Instance.Settings.IPConfiguration.AuthorizedNetworks = networks
+
+	return nil
 }
 
-func (c *Connection) modifySSLPolicy(status bool) (err error) {
+func (c *Connection) modifySSLPolicy(ctx context.Context, status bool) (err error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
 	request := TemplatedHTTPRequest{
 		urlText: sslRequestURLTemplate,
 		urlData: struct {
@@ -202,7 +309,7 @@ func (c *Connection) modifySSLPolicy(status bool) (err error) {
 			c.Instance.Name,
 		},
 		headers: map[string]string{
-			"Authorization": "Bearer " + c.accessToken.token,
+			"Authorization": "Bearer " + bearer,
 			"Content-Type":  "application/json",
 		},
 		bodyText: sslRequestBodyTemplate,
@@ -213,21 +320,32 @@ func (c *Connection) modifySSLPolicy(status bool) (err error) {
 		},
 	}
 
-	c.httpRequest, err = NewHTTPRequest("PATCH", request)
+	c.httpRequest, err = NewHTTPRequest(ctx, "PATCH", request)
 	if err != nil {
 		return
 	}
 
-	err = ParseHTTPRequest(c.httpRequest, &c.response)
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
 	if err != nil {
 		return
 	}
 
-	return c.waitUntilDone()
+	if err = c.waitUntilDone(ctx); err != nil {
+		return
+	}
+
+	c.Instance.Settings.IPConfiguration.RequireSsl = status
+
+	return nil
 }
 
 // SetUserPassword : sets a specified users password
-func (c *Connection) SetUserPassword(user string, password string) (err error) {
+func (c *Connection) SetUserPassword(ctx context.Context, user string, password string) (err error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
 	request := TemplatedHTTPRequest{
 		urlText: pwRequestURLTemplate,
 		urlData: struct {
@@ -240,7 +358,7 @@ func (c *Connection) SetUserPassword(user string, password string) (err error) {
 			user,
 		},
 		headers: map[string]string{
-			"Authorization": "Bearer " + c.accessToken.token,
+			"Authorization": "Bearer " + bearer,
 			"Content-Type":  "application/json",
 		},
 		bodyText: pwRequestBodyTemplate,
@@ -253,55 +371,96 @@ func (c *Connection) SetUserPassword(user string, password string) (err error) {
 		},
 	}
 
-	c.httpRequest, err = NewHTTPRequest("PUT", request)
+	c.httpRequest, err = NewHTTPRequest(ctx, "PUT", request)
 	if err != nil {
 		return
 	}
 
-	err = ParseHTTPRequest(c.httpRequest, &c.response)
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
 	if err != nil {
 		return
 	}
 
-	return c.waitUntilDone()
+	return c.waitUntilDone(ctx)
 }
 
-func (c *Connection) waitUntilDone() (err error) {
+// waitUntilDone : Polls the pending operation's selfLink until its status is
+// DONE, backing off exponentially (with jitter) between polls, capped at
+// maxPollInterval. If ctx is cancelled before completion it returns ctx.Err()
+// when cancelled between polls, or the HTTP client's error wrapping it when a
+// poll request is in flight.
+func (c *Connection) waitUntilDone(ctx context.Context) (err error) {
 	if c.response == (Response{}) {
 		return errors.New("Connection response is empty")
 	}
 
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
 	request := TemplatedHTTPRequest{
 		urlText: c.response.SelfLink,
 		headers: map[string]string{
-			"Authorization": "Bearer " + c.accessToken.token,
+			"Authorization": "Bearer " + bearer,
 			"Content-Type":  "application/json",
 		},
 	}
 
-	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
-	s.Prefix = fmt.Sprintf("Waiting for %s operation to complete ", c.response.OperationType)
-	s.FinalMSG = fmt.Sprintf("%s✓\n", s.Prefix)
-	s.Start()
-	defer s.Stop()
+	prefix := fmt.Sprintf("Waiting for %s operation to complete", c.response.OperationType)
+	c.progress.Start(prefix)
+	defer c.progress.Stop(prefix + " done")
+
+	start := c.clock.Now()
+	lastStatus := c.response.Status
+	infoLogger.Printf("operation %s (%s): %s", c.response.Name, c.response.OperationType, lastStatus)
+	defer func() {
+		infoLogger.Printf("operation %s (%s): waited %s", c.response.Name, c.response.OperationType, c.clock.Now().Sub(start))
+	}()
+
+	interval := c.pollInterval
 	for c.response.Status != "DONE" {
-		time.Sleep(1 * time.Second)
+		if err := c.sleeper.Sleep(ctx, jitter(interval)); err != nil {
+			return err
+		}
 
-		httpRequest, err := NewHTTPRequest("GET", request)
+		httpRequest, err := NewHTTPRequest(ctx, "GET", request)
 		if err != nil {
 			return err
 		}
 
-		err = ParseHTTPRequest(httpRequest, &c.response)
-
+		err = ParseHTTPRequest(c.httpClient, httpRequest, &c.response)
 		if err != nil {
 			return err
 		}
+
+		if c.response.Status != lastStatus {
+			infoLogger.Printf("operation %s (%s): %s -> %s", c.response.Name, c.response.OperationType, lastStatus, c.response.Status)
+			lastStatus = c.response.Status
+		}
+
+		interval = nextPollInterval(interval)
 	}
 
 	return nil
 }
 
+// jitter : Returns a duration randomized within [d/2, 3d/2) to avoid
+// thundering-herd polling against the SQL Admin API
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
+}
+
+// nextPollInterval : Doubles d, capped at maxPollInterval
+func nextPollInterval(d time.Duration) time.Duration {
+	next := d * 2
+	if next > maxPollInterval {
+		next = maxPollInterval
+	}
+
+	return next
+}
+
 func (s SQLInstance) GetPublicIP() (ip string, err error) {
 	for _, addr := range s.IPAddresses {
 		if addr.Type == "PRIMARY" {
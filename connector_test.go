@@ -0,0 +1,243 @@
+package gcloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/eolso/gcloudsql/gcloudsqltest"
+)
+
+// staticTokenSource : oauth2.TokenSource that always returns the same token
+type staticTokenSource string
+
+func (s staticTokenSource) Token() (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: string(s)}, nil
+}
+
+// instantSleeper : Sleeper that never actually waits, so waitUntilDone's
+// polling loop runs at test speed
+type instantSleeper struct{}
+
+func (instantSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
+
+func newTestConnection(server *gcloudsqltest.Server, instance SQLInstance) Connection {
+	return Connection{
+		Instance:     instance,
+		tokenSource:  staticTokenSource("test-token"),
+		httpClient:   &http.Client{Transport: server.Transport()},
+		pollInterval: time.Millisecond,
+		progress:     noopProgressReporter{},
+		clock:        realClock{},
+		sleeper:      instantSleeper{},
+		lock:         new(sync.Mutex),
+	}
+}
+
+func doneOperation() map[string]interface{} {
+	return map[string]interface{}{
+		"kind":          "sql#operation",
+		"status":        "DONE",
+		"operationType": "UPDATE",
+		"selfLink":      "https://www.googleapis.com/sql/v1beta4/operations/done",
+	}
+}
+
+func TestWhitelistBlacklistIPOrdering(t *testing.T) {
+	cases := []struct {
+		name      string
+		initial   []AuthorizedNetwork
+		whitelist *AuthorizedNetwork
+		blacklist string
+		want      []string
+	}{
+		{
+			name:      "whitelist appends to the end",
+			initial:   []AuthorizedNetwork{{Name: "a", Value: "10.0.0.1"}},
+			whitelist: &AuthorizedNetwork{Name: "b", Value: "10.0.0.2"},
+			want:      []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:      "blacklist removes the matching entry and preserves the rest",
+			initial:   []AuthorizedNetwork{{Name: "a", Value: "10.0.0.1"}, {Name: "b", Value: "10.0.0.2"}, {Name: "c", Value: "10.0.0.3"}},
+			blacklist: "10.0.0.2",
+			want:      []string{"10.0.0.1", "10.0.0.3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodPatch, "/sql/v1beta4/projects/proj/instances/inst", http.StatusOK, doneOperation())
+
+			instance := SQLInstance{Project: "proj", Name: "inst"}
+			instance.Settings.IPConfiguration.AuthorizedNetworks = tc.initial
+
+			c := newTestConnection(server, instance)
+
+			var err error
+			if tc.whitelist != nil {
+				err = c.WhitelistIP(context.Background(), tc.whitelist.Name, tc.whitelist.Value)
+			} else {
+				err = c.BlacklistIP(context.Background(), tc.blacklist)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, n := range c.Instance.Settings.IPConfiguration.AuthorizedNetworks {
+				got = append(got, n.Value)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+
+			requests := server.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 PATCH request, got %d", len(requests))
+			}
+
+			var sent struct {
+				Settings struct {
+					IPConfiguration struct {
+						AuthorizedNetworks []struct {
+							Value string `json:"value"`
+							Name  string `json:"name"`
+						} `json:"authorizedNetworks"`
+					} `json:"ipConfiguration"`
+				} `json:"settings"`
+			}
+			if err := json.Unmarshal(requests[0].Body, &sent); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+
+			var sentValues []string
+			for _, n := range sent.Settings.IPConfiguration.AuthorizedNetworks {
+				sentValues = append(sentValues, n.Value)
+			}
+			if len(sentValues) != len(tc.want) {
+				t.Fatalf("request body authorizedNetworks %v, want %v", sentValues, tc.want)
+			}
+			for i := range sentValues {
+				if sentValues[i] != tc.want[i] {
+					t.Fatalf("request body authorizedNetworks %v, want %v", sentValues, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSSLPolicyIdempotent(t *testing.T) {
+	server := gcloudsqltest.NewServer()
+	defer server.Close()
+
+	server.Handle(http.MethodPatch, "/sql/v1beta4/projects/proj/instances/inst", http.StatusOK, doneOperation())
+	server.Handle(http.MethodPatch, "/sql/v1beta4/projects/proj/instances/inst", http.StatusOK, doneOperation())
+
+	c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+	if err := c.EnableSSL(context.Background()); err != nil {
+		t.Fatalf("first EnableSSL: %v", err)
+	}
+	if !c.Instance.Settings.IPConfiguration.RequireSsl {
+		t.Fatal("expected RequireSsl to be true after first EnableSSL")
+	}
+	if err := c.EnableSSL(context.Background()); err != nil {
+		t.Fatalf("second EnableSSL: %v", err)
+	}
+	if !c.Instance.Settings.IPConfiguration.RequireSsl {
+		t.Fatal("expected RequireSsl to be true after second EnableSSL")
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 PATCH requests, got %d", len(requests))
+	}
+}
+
+// pendingOperation : A sql#operation still in flight, polled via the
+// selfLink below until it flips to DONE
+func pendingOperation() map[string]interface{} {
+	return map[string]interface{}{
+		"kind":          "sql#operation",
+		"status":        "PENDING",
+		"operationType": "UPDATE",
+		"selfLink":      "https://www.googleapis.com/sql/v1beta4/operations/poll",
+	}
+}
+
+func TestWaitUntilDonePollsPendingToDone(t *testing.T) {
+	server := gcloudsqltest.NewServer()
+	defer server.Close()
+
+	server.Handle(http.MethodPatch, "/sql/v1beta4/projects/proj/instances/inst", http.StatusOK, pendingOperation())
+	server.Handle(http.MethodGet, "/sql/v1beta4/operations/poll", http.StatusOK, pendingOperation())
+	server.Handle(http.MethodGet, "/sql/v1beta4/operations/poll", http.StatusOK, doneOperation())
+
+	c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+	if err := c.EnableSSL(context.Background()); err != nil {
+		t.Fatalf("EnableSSL: %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 1 PATCH + 2 GET polls, got %d requests: %+v", len(requests), requests)
+	}
+	if requests[0].Method != http.MethodPatch {
+		t.Fatalf("expected first request to be the PATCH, got %s %s", requests[0].Method, requests[0].Path)
+	}
+	if requests[1].Method != http.MethodGet || requests[2].Method != http.MethodGet {
+		t.Fatalf("expected the operation to be polled via GET, got %+v", requests[1:])
+	}
+}
+
+func TestParseHTTPRequestDecodesAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"code":403,"message":"Quota exceeded","errors":[{"domain":"usageLimits","reason":"quotaExceeded","message":"Quota exceeded"}]}}`))
+	}))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	var out struct{}
+	err = ParseHTTPRequest(nil, req, &out)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	apiErr, ok := err.(APIError)
+	if !ok {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.Code != 403 || apiErr.Reason() != "quotaExceeded" {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+}
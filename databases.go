@@ -0,0 +1,140 @@
+package gcloudsql
+
+import "context"
+
+// Database : Struct for storing sql database data
+type Database struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Instance  string `json:"instance"`
+	Project   string `json:"project"`
+	Charset   string `json:"charset"`
+	Collation string `json:"collation"`
+	Etag      string `json:"etag"`
+	SelfLink  string `json:"selfLink"`
+}
+
+// databasesListResponse : Envelope returned by the SQL Admin databases.list endpoint
+type databasesListResponse struct {
+	Kind  string     `json:"kind"`
+	Items []Database `json:"items"`
+}
+
+// ListDatabases : Lists the databases configured on the instance
+func (c *Connection) ListDatabases(ctx context.Context) ([]Database, error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return nil, err
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: databasesRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	httpRequest, err := NewHTTPRequest(ctx, "GET", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResponse databasesListResponse
+	if err := ParseHTTPRequest(c.httpClient, httpRequest, &listResponse); err != nil {
+		return nil, err
+	}
+
+	return listResponse.Items, nil
+}
+
+// CreateDatabase : Creates a new database with the given name
+func (c *Connection) CreateDatabase(ctx context.Context, name string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: databasesRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+		bodyText: databaseInsertBodyTemplate,
+		bodyData: struct {
+			Name string
+		}{
+			name,
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "POST", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
+
+// DeleteDatabase : Deletes the database with the given name
+func (c *Connection) DeleteDatabase(ctx context.Context, name string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: databaseRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+			Name         string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+			name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "DELETE", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
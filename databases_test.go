@@ -0,0 +1,138 @@
+package gcloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/eolso/gcloudsql/gcloudsqltest"
+)
+
+func TestListDatabases(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body: map[string]interface{}{
+				"kind": "sql#databasesList",
+				"items": []map[string]interface{}{
+					{"kind": "sql#database", "name": "app", "instance": "inst", "project": "proj", "charset": "utf8"},
+				},
+			},
+		},
+		{
+			name:       "api error",
+			statusCode: http.StatusForbidden,
+			body:       apiErrorBody("quotaExceeded"),
+			wantErr:    "quotaExceeded",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodGet, "/sql/v1beta4/projects/proj/instances/inst/databases", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			databases, err := c.ListDatabases(context.Background())
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(databases) != 1 || databases[0].Name != "app" {
+				t.Fatalf("unexpected databases: %+v", databases)
+			}
+		})
+	}
+}
+
+func TestCreateDatabase(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusForbidden, body: apiErrorBody("invalid"), wantErr: "invalid"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodPost, "/sql/v1beta4/projects/proj/instances/inst/databases", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.CreateDatabase(context.Background(), "app")
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			requests := server.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(requests))
+			}
+
+			var sent struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(requests[0].Body, &sent); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if sent.Name != "app" {
+				t.Fatalf("unexpected request body: %+v", sent)
+			}
+		})
+	}
+}
+
+func TestDeleteDatabase(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusNotFound, body: apiErrorBody("notFound"), wantErr: "notFound"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodDelete, "/sql/v1beta4/projects/proj/instances/inst/databases/app", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.DeleteDatabase(context.Background(), "app")
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
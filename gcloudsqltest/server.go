@@ -0,0 +1,129 @@
+// Package gcloudsqltest provides a fake SQL Admin API backed by
+// httptest.Server for exercising a gcloudsql.Connection without hitting
+// live GCP.
+package gcloudsqltest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+// RecordedRequest : A single HTTP request observed by the fake server
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Response : A canned response to return for a registered method+path
+type Response struct {
+	StatusCode int
+	Body       interface{}
+}
+
+// Server : A fake SQL Admin API. Register responses with Handle, then point
+// a gcloudsql.Connection at it via ConnectionOptions.Transport: Server.Transport().
+type Server struct {
+	httpServer *httptest.Server
+
+	mu        sync.Mutex
+	requests  []RecordedRequest
+	responses map[string][]Response
+}
+
+// NewServer : Starts the fake server. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{responses: make(map[string][]Response)}
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close : Shuts down the underlying httptest.Server
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Transport : An http.RoundTripper that rewrites any request's scheme and
+// host to this server while leaving path and query untouched, so
+// gcloudsql's hard-coded googleapis.com URLs land here instead
+func (s *Server) Transport() http.RoundTripper {
+	target, err := url.Parse(s.httpServer.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		redirected := req.Clone(req.Context())
+		redirected.URL.Scheme = target.Scheme
+		redirected.URL.Host = target.Host
+		redirected.Host = target.Host
+
+		return http.DefaultTransport.RoundTrip(redirected)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Handle : Queues a response to return for the next request matching
+// method+path, in the order Handle was called. The last queued response for
+// a key repeats once the queue is exhausted, which makes replaying a
+// PENDING -> RUNNING -> DONE polling sequence a matter of calling Handle
+// once per step.
+func (s *Server) Handle(method string, path string, statusCode int, body interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := method + " " + path
+	s.responses[key] = append(s.responses[key], Response{StatusCode: statusCode, Body: body})
+}
+
+// Requests : Returns every request recorded so far, in arrival order
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]RecordedRequest, len(s.requests))
+	copy(out, s.requests)
+
+	return out
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+
+	key := r.Method + " " + r.URL.Path
+	queue := s.responses[key]
+
+	var resp Response
+	switch len(queue) {
+	case 0:
+		resp = Response{StatusCode: http.StatusNotFound, Body: map[string]string{"error": "gcloudsqltest: no response registered for " + key}}
+	case 1:
+		resp = queue[0]
+	default:
+		resp = queue[0]
+		s.responses[key] = queue[1:]
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	_ = json.NewEncoder(w).Encode(resp.Body)
+}
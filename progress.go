@@ -0,0 +1,49 @@
+package gcloudsql
+
+import (
+	"time"
+
+	"github.com/briandowns/spinner"
+)
+
+// ProgressReporter : Notified as waitUntilDone starts and stops polling a
+// long-running operation. Implementations that render to a terminal should
+// no-op outside of an interactive session so headless/CI callers aren't
+// forced to emit terminal escapes.
+type ProgressReporter interface {
+	Start(message string)
+	Stop(finalMessage string)
+}
+
+// noopProgressReporter : Default ProgressReporter; reports nothing
+type noopProgressReporter struct{}
+
+func (noopProgressReporter) Start(string) {}
+func (noopProgressReporter) Stop(string)  {}
+
+// spinnerProgressReporter : Renders a terminal spinner via briandowns/spinner
+type spinnerProgressReporter struct {
+	spinner *spinner.Spinner
+}
+
+// NewSpinnerProgressReporter : Builds a ProgressReporter that renders a
+// terminal spinner, matching the behavior gcloudsql had before it became
+// pluggable. Opt into this explicitly via ConnectionOptions.ProgressReporter
+// for interactive CLI use; it is not the default.
+func NewSpinnerProgressReporter() ProgressReporter {
+	return &spinnerProgressReporter{
+		spinner: spinner.New(spinner.CharSets[14], 100*time.Millisecond),
+	}
+}
+
+func (r *spinnerProgressReporter) Start(message string) {
+	r.spinner.Prefix = message + " "
+	r.spinner.Start()
+}
+
+func (r *spinnerProgressReporter) Stop(finalMessage string) {
+	if finalMessage != "" {
+		r.spinner.FinalMSG = finalMessage + "\n"
+	}
+	r.spinner.Stop()
+}
@@ -2,11 +2,13 @@ package gcloudsql
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -26,8 +28,6 @@ const sslRequestBodyTemplate = `{
 	}
 }`
 
-const tokenRequestURLTemplate = `https://www.googleapis.com/oauth2/v1/tokeninfo?access_token={{.AccessToken}}`
-
 const instanceRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}`
 const instanceRequestBodyTemplate = `{
 	"settings": {
@@ -42,6 +42,37 @@ const instanceRequestBodyTemplate = `{
 	}
 }`
 
+const usersRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/users`
+const userInsertBodyTemplate = `{
+	"name": "{{.Name}}",
+	"password": "{{.Password}}"
+}`
+const userDeleteURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/users?name={{.Name}}`
+
+const databasesRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/databases`
+const databaseRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/databases/{{.Name}}`
+const databaseInsertBodyTemplate = `{
+	"name": "{{.Name}}"
+}`
+
+const sslCertsRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/sslCerts`
+const sslCertInsertBodyTemplate = `{
+	"commonName": "{{.CommonName}}"
+}`
+const sslCertRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/sslCerts/{{.Sha1Fingerprint}}`
+
+const backupRunsRequestURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/backupRuns`
+const backupRunsInsertBodyTemplate = `{
+	"kind": "sql#backupRun"
+}`
+const restoreBackupURLTemplate = `https://www.googleapis.com/sql/v1beta4/projects/{{.ProjectID}}/instances/{{.InstanceName}}/restoreBackup`
+const restoreBackupBodyTemplate = `{
+	"restoreBackupContext": {
+		"kind": "sql#restoreBackupContext",
+		"backupRunId": "{{.BackupRunID}}"
+	}
+}`
+
 // TemplatedHTTPRequest : Struct for creating http requests through templates
 type TemplatedHTTPRequest struct {
 	headers map[string]string
@@ -53,8 +84,9 @@ type TemplatedHTTPRequest struct {
 	bodyData interface{}
 }
 
-// NewHTTPRequest : Creates a new *http.Request using templates
-func NewHTTPRequest(method string, request TemplatedHTTPRequest) (*http.Request, error) {
+// NewHTTPRequest : Creates a new *http.Request using templates, bound to ctx
+// so callers can cancel or time out the eventual round trip
+func NewHTTPRequest(ctx context.Context, method string, request TemplatedHTTPRequest) (*http.Request, error) {
 	var url string
 
 	if request.urlText != "" {
@@ -85,7 +117,7 @@ func NewHTTPRequest(method string, request TemplatedHTTPRequest) (*http.Request,
 		body = bytes.NewReader(bodyBuffer.Bytes())
 	}
 
-	httpRequest, err := http.NewRequest(method, url, body)
+	httpRequest, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -94,16 +126,31 @@ func NewHTTPRequest(method string, request TemplatedHTTPRequest) (*http.Request,
 		httpRequest.Header.Add(key, val)
 	}
 
+	debugLogger.Printf("built %s request for %s", method, url)
+
 	return httpRequest, nil
 }
 
-// ParseHTTPRequest : Parses the response from a http request and stores the
-// output in v
-func ParseHTTPRequest(request *http.Request, v interface{}) error {
-	response, err := http.DefaultClient.Do(request)
+// ParseHTTPRequest : Executes request using client (http.DefaultClient if
+// client is nil), parses the response, and stores the output in v. Non-200
+// responses are decoded against the SQL Admin API's error envelope and
+// returned as an APIError when that succeeds.
+func ParseHTTPRequest(client *http.Client, request *http.Request, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	start := time.Now()
+	response, err := client.Do(request)
+	latency := time.Since(start)
+
 	if err != nil {
+		debugLogger.Printf("%s %s failed after %s: %v", request.Method, request.URL, latency, err)
 		return err
 	}
+	defer response.Body.Close()
+
+	debugLogger.Printf("%s %s -> %s (%s)", request.Method, request.URL, response.Status, latency)
 
 	responseBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
@@ -111,6 +158,11 @@ func ParseHTTPRequest(request *http.Request, v interface{}) error {
 	}
 
 	if response.StatusCode != http.StatusOK {
+		var envelope apiErrorEnvelope
+		if jsonErr := json.Unmarshal(responseBody, &envelope); jsonErr == nil && envelope.Error.Message != "" {
+			return envelope.Error
+		}
+
 		return errors.New("request returned " + response.Status)
 	}
 
@@ -0,0 +1,222 @@
+package gcloudsql
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// SSLCert : Struct for storing sql ssl cert data. CertPrivateKey is only
+// populated on the response to CreateSSLCert; the SQL Admin API does not
+// return it from any other endpoint, so it must be persisted by the caller.
+type SSLCert struct {
+	Kind             string `json:"kind"`
+	CommonName       string `json:"commonName"`
+	Sha1Fingerprint  string `json:"sha1Fingerprint"`
+	Instance         string `json:"instance"`
+	Cert             string `json:"cert"`
+	CertSerialNumber string `json:"certSerialNumber"`
+	CreateTime       string `json:"createTime"`
+	ExpirationTime   string `json:"expirationTime"`
+	SelfLink         string `json:"selfLink"`
+	CertPrivateKey   string `json:"certPrivateKey,omitempty"`
+}
+
+// sslCertsListResponse : Envelope returned by the SQL Admin sslCerts.list endpoint
+type sslCertsListResponse struct {
+	Kind  string    `json:"kind"`
+	Items []SSLCert `json:"items"`
+}
+
+// sslCertDetail : Shape of the `clientCert` field on sslCerts.insert, which
+// nests the cert fields under certInfo rather than returning a flat SSLCert.
+type sslCertDetail struct {
+	CertInfo       SSLCert `json:"certInfo"`
+	CertPrivateKey string  `json:"certPrivateKey"`
+}
+
+// sslCertInsertResponse : Envelope returned by the SQL Admin sslCerts.insert
+// endpoint. The client cert and private key it carries are never returned
+// again, so callers must capture them from here.
+type sslCertInsertResponse struct {
+	Kind         string        `json:"kind"`
+	ClientCert   sslCertDetail `json:"clientCert"`
+	ServerCaCert SSLCert       `json:"serverCaCert"`
+}
+
+// ClientCertificate : The server CA and client cert/key pair needed to
+// connect to an instance with requireSsl enabled
+type ClientCertificate struct {
+	ServerCaCert SSLCert
+	ClientCert   SSLCert
+}
+
+// ListSSLCerts : Lists the client certificates authorized to connect to the instance
+func (c *Connection) ListSSLCerts(ctx context.Context) ([]SSLCert, error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return nil, err
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: sslCertsRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	httpRequest, err := NewHTTPRequest(ctx, "GET", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResponse sslCertsListResponse
+	if err := ParseHTTPRequest(c.httpClient, httpRequest, &listResponse); err != nil {
+		return nil, err
+	}
+
+	return listResponse.Items, nil
+}
+
+// CreateSSLCert : Creates a new client certificate with the given common
+// name and returns the full certificate chain needed to connect over SSL.
+// The returned private key is not retrievable again, so callers should
+// persist it immediately (see ClientCertificate.WriteToDir).
+func (c *Connection) CreateSSLCert(ctx context.Context, commonName string) (ClientCertificate, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return ClientCertificate{}, err
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: sslCertsRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+		bodyText: sslCertInsertBodyTemplate,
+		bodyData: struct {
+			CommonName string
+		}{
+			commonName,
+		},
+	}
+
+	httpRequest, err := NewHTTPRequest(ctx, "POST", request)
+	if err != nil {
+		return ClientCertificate{}, err
+	}
+
+	var insertResponse sslCertInsertResponse
+	if err := ParseHTTPRequest(c.httpClient, httpRequest, &insertResponse); err != nil {
+		return ClientCertificate{}, err
+	}
+
+	clientCert := insertResponse.ClientCert.CertInfo
+	clientCert.CertPrivateKey = insertResponse.ClientCert.CertPrivateKey
+
+	return ClientCertificate{
+		ServerCaCert: insertResponse.ServerCaCert,
+		ClientCert:   clientCert,
+	}, nil
+}
+
+// DeleteSSLCert : Revokes the client certificate identified by sha1Fingerprint
+func (c *Connection) DeleteSSLCert(ctx context.Context, sha1Fingerprint string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: sslCertRequestURLTemplate,
+		urlData: struct {
+			ProjectID       string
+			InstanceName    string
+			Sha1Fingerprint string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+			sha1Fingerprint,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "DELETE", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
+
+// TLSConfig : Builds a *tls.Config trusting the server CA and presenting the
+// client certificate, suitable for passing to a database/sql driver that
+// supports a custom tls.Config (e.g. go-sql-driver/mysql, lib/pq)
+func (cc ClientCertificate) TLSConfig() (*tls.Config, error) {
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM([]byte(cc.ServerCaCert.Cert)) {
+		return nil, errors.New("gcloudsql: failed to parse server CA certificate")
+	}
+
+	clientCert, err := tls.X509KeyPair([]byte(cc.ClientCert.Cert), []byte(cc.ClientCert.CertPrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		RootCAs:      caPool,
+		Certificates: []tls.Certificate{clientCert},
+	}, nil
+}
+
+// WriteToDir : Writes server-ca.pem, client-cert.pem, and client-key.pem into
+// dir, matching the layout expected by the Cloud SQL proxy and the
+// MySQL/Postgres drivers
+func (cc ClientCertificate) WriteToDir(dir string) error {
+	files := map[string]string{
+		"server-ca.pem":   cc.ServerCaCert.Cert,
+		"client-cert.pem": cc.ClientCert.Cert,
+		"client-key.pem":  cc.ClientCert.CertPrivateKey,
+	}
+
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
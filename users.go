@@ -0,0 +1,140 @@
+package gcloudsql
+
+import "context"
+
+// User : Struct for storing sql user data
+type User struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Instance string `json:"instance"`
+	Project  string `json:"project"`
+	Etag     string `json:"etag"`
+}
+
+// usersListResponse : Envelope returned by the SQL Admin users.list endpoint
+type usersListResponse struct {
+	Kind  string `json:"kind"`
+	Items []User `json:"items"`
+}
+
+// ListUsers : Lists the users configured on the instance
+func (c *Connection) ListUsers(ctx context.Context) ([]User, error) {
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return nil, err
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: usersRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	httpRequest, err := NewHTTPRequest(ctx, "GET", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResponse usersListResponse
+	if err := ParseHTTPRequest(c.httpClient, httpRequest, &listResponse); err != nil {
+		return nil, err
+	}
+
+	return listResponse.Items, nil
+}
+
+// CreateUser : Creates a new user with the given name and password
+func (c *Connection) CreateUser(ctx context.Context, name string, password string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: usersRequestURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+		bodyText: userInsertBodyTemplate,
+		bodyData: struct {
+			Name     string
+			Password string
+		}{
+			name,
+			password,
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "POST", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
+
+// DeleteUser : Deletes the user with the given name
+func (c *Connection) DeleteUser(ctx context.Context, name string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bearer, err := bearerToken(c.tokenSource)
+	if err != nil {
+		return
+	}
+
+	request := TemplatedHTTPRequest{
+		urlText: userDeleteURLTemplate,
+		urlData: struct {
+			ProjectID    string
+			InstanceName string
+			Name         string
+		}{
+			c.Instance.Project,
+			c.Instance.Name,
+			name,
+		},
+		headers: map[string]string{
+			"Authorization": "Bearer " + bearer,
+			"Content-Type":  "application/json",
+		},
+	}
+
+	c.httpRequest, err = NewHTTPRequest(ctx, "DELETE", request)
+	if err != nil {
+		return
+	}
+
+	err = ParseHTTPRequest(c.httpClient, c.httpRequest, &c.response)
+	if err != nil {
+		return
+	}
+
+	return c.waitUntilDone(ctx)
+}
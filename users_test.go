@@ -0,0 +1,166 @@
+package gcloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/eolso/gcloudsql/gcloudsqltest"
+)
+
+// apiErrorBody : A canned SQL Admin error envelope carrying reason as the
+// first error detail, for exercising the APIError decode path
+func apiErrorBody(reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    403,
+			"message": "forbidden",
+			"errors": []map[string]interface{}{
+				{"domain": "usageLimits", "reason": reason, "message": "forbidden"},
+			},
+		},
+	}
+}
+
+// assertAPIError : Fails t unless err is an APIError with the given Reason
+func assertAPIError(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	apiErr, ok := err.(APIError)
+	if !ok {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.Reason() != reason {
+		t.Fatalf("expected reason %q, got %q", reason, apiErr.Reason())
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body: map[string]interface{}{
+				"kind": "sql#usersList",
+				"items": []map[string]interface{}{
+					{"kind": "sql#user", "name": "app", "host": "%", "instance": "inst", "project": "proj"},
+				},
+			},
+		},
+		{
+			name:       "api error",
+			statusCode: http.StatusForbidden,
+			body:       apiErrorBody("quotaExceeded"),
+			wantErr:    "quotaExceeded",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodGet, "/sql/v1beta4/projects/proj/instances/inst/users", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			users, err := c.ListUsers(context.Background())
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(users) != 1 || users[0].Name != "app" {
+				t.Fatalf("unexpected users: %+v", users)
+			}
+		})
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusForbidden, body: apiErrorBody("invalid"), wantErr: "invalid"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodPost, "/sql/v1beta4/projects/proj/instances/inst/users", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.CreateUser(context.Background(), "app", "hunter2")
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			requests := server.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(requests))
+			}
+
+			var sent struct {
+				Name     string `json:"name"`
+				Password string `json:"password"`
+			}
+			if err := json.Unmarshal(requests[0].Body, &sent); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if sent.Name != "app" || sent.Password != "hunter2" {
+				t.Fatalf("unexpected request body: %+v", sent)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       interface{}
+		wantErr    string
+	}{
+		{name: "success", statusCode: http.StatusOK, body: doneOperation()},
+		{name: "api error", statusCode: http.StatusNotFound, body: apiErrorBody("notFound"), wantErr: "notFound"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gcloudsqltest.NewServer()
+			defer server.Close()
+
+			server.Handle(http.MethodDelete, "/sql/v1beta4/projects/proj/instances/inst/users", tc.statusCode, tc.body)
+
+			c := newTestConnection(server, SQLInstance{Project: "proj", Name: "inst"})
+
+			err := c.DeleteUser(context.Background(), "app")
+			if tc.wantErr != "" {
+				assertAPIError(t, err, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}